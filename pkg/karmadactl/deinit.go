@@ -7,11 +7,20 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	aggregatorclientset "k8s.io/kube-aggregator/pkg/client/clientset_generated/clientset"
 
 	"github.com/karmada-io/karmada/pkg/karmadactl/cmdinit/utils"
 	"github.com/karmada-io/karmada/pkg/karmadactl/options"
@@ -21,14 +30,43 @@ import (
 const (
 	LabelSelector    = "karmada.io/bootstrapping"
 	karmadaNodeLabel = "karmada.io/etcd"
+
+	// finalizerPrefix matches custom finalizers left behind by Karmada controllers,
+	// e.g. "karmada.io/resourcebinding-controller".
+	finalizerPrefix = "karmada.io/"
+
+	// defaultFinalizerTimeout is how long deinit waits for a stuck resource to
+	// finalize on its own before it force-patches the finalizers off.
+	defaultFinalizerTimeout = 30 * time.Second
 )
 
 // CommandDeInitOption options for deinit.
 type CommandDeInitOption struct {
 	options.GlobalCommandOptions
-	Namespace     string
-	Context       string
-	KubeClientSet *kubernetes.Clientset
+	Namespace           string
+	Context             string
+	PurgeCRDs           bool
+	FinalizerTimeout    time.Duration
+	WaitTimeout         time.Duration
+	FromManifest        string
+	EtcdSnapshotDir     string
+	PurgeEtcdPVCs       bool
+	PurgeEtcdHostPath   bool
+	Yes                 bool
+	Force               bool
+	Output              string
+	KubeClientSet       *kubernetes.Clientset
+	APIExtensionsClient apiextensionsclientset.Interface
+	AggregatorClient    aggregatorclientset.Interface
+	DynamicClient       dynamic.Interface
+	RestConfig          *rest.Config
+
+	// pending tracks resources handed to the API server for deletion so waitForDeletion
+	// can confirm they actually disappeared.
+	pending []pendingResource
+
+	// report accumulates the outcome of every object considered, for -o json|yaml.
+	report []resourceReport
 }
 
 // NewCmdDeInit removes Karmada from Kubernetes
@@ -54,6 +92,16 @@ func NewCmdDeInit(cmdOut io.Writer, parentCommand string) *cobra.Command {
 	flags := cmd.PersistentFlags()
 	flags.StringVarP(&opts.Namespace, "namespace", "n", "karmada-system", "namespace where Karmada components are installed.")
 	flags.StringVar(&opts.Context, "context", "", "The name of the kubeconfig context to use")
+	flags.BoolVar(&opts.PurgeCRDs, "purge-crds", false, "purge Karmada CRDs and their instances across all namespaces. Without this flag CRDs are left installed.")
+	flags.DurationVar(&opts.FinalizerTimeout, "finalizer-timeout", defaultFinalizerTimeout, "how long to wait for a resource stuck on a karmada.io finalizer before force-removing the finalizer and retrying the delete.")
+	flags.DurationVar(&opts.WaitTimeout, "wait-timeout", defaultWaitTimeout, "how long to block after issuing deletes, waiting for resources to actually disappear from the API server.")
+	flags.StringVar(&opts.FromManifest, "from-manifest", "", "path to the manifest recorded by `karmadactl init`. When set, deinit reverses exactly what's in the manifest instead of sweeping by the karmada.io/bootstrapping label.")
+	flags.StringVar(&opts.EtcdSnapshotDir, "etcd-snapshot", "", "directory to save an etcd snapshot to before tearing down etcd. Leave empty to skip taking a snapshot.")
+	flags.BoolVar(&opts.PurgeEtcdPVCs, "purge-etcd-pvcs", false, "delete the PersistentVolumeClaims backing etcd after the etcd StatefulSet is removed.")
+	flags.BoolVar(&opts.PurgeEtcdHostPath, "purge-etcd-hostpath", false, "remove the hostPath directory used by local etcd on nodes labeled karmada.io/etcd, then remove the node label.")
+	flags.BoolVarP(&opts.Yes, "yes", "y", false, "skip the interactive confirmation prompt and proceed with deletion.")
+	flags.BoolVar(&opts.Force, "force", false, "continue past per-resource deletion errors instead of aborting, and report them at the end.")
+	flags.StringVarP(&opts.Output, "output", "o", "", "output format for the structured deletion report. One of: json, yaml.")
 	return cmd
 }
 
@@ -68,6 +116,12 @@ func deInitExample(parentCommand string) string {
 
 // Complete the conditions required to be able to run deinit.
 func (o *CommandDeInitOption) Complete() error {
+	switch o.Output {
+	case "", "json", "yaml":
+	default:
+		return fmt.Errorf("unsupported output format %q, must be one of: json, yaml", o.Output)
+	}
+
 	if o.KubeConfig == "" {
 		o.KubeConfig = filepath.Join(os.Getenv("HOME"), ".kube/config")
 	}
@@ -80,12 +134,28 @@ func (o *CommandDeInitOption) Complete() error {
 	if err != nil {
 		return err
 	}
+	o.RestConfig = restConfig
 
 	o.KubeClientSet, err = utils.NewClientSet(restConfig)
 	if err != nil {
 		return err
 	}
 
+	o.APIExtensionsClient, err = apiextensionsclientset.NewForConfig(restConfig)
+	if err != nil {
+		return err
+	}
+
+	o.AggregatorClient, err = aggregatorclientset.NewForConfig(restConfig)
+	if err != nil {
+		return err
+	}
+
+	o.DynamicClient, err = dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return err
+	}
+
 	if _, err := o.KubeClientSet.CoreV1().Namespaces().Get(context.TODO(), o.Namespace, metav1.GetOptions{}); err != nil {
 		return err
 	}
@@ -107,11 +177,9 @@ func (o *CommandDeInitOption) delete() error {
 	}
 
 	for _, service := range services.Items {
-		fmt.Printf("delete Service %q\n", service.Name)
-		if o.DryRun {
-			continue
-		}
-		if err := serviceClient.Delete(context.TODO(), service.Name, metav1.DeleteOptions{}); err != nil {
+		if err := o.applyDelete("Service", o.Namespace, service.Name, func() error {
+			return serviceClient.Delete(context.TODO(), service.Name, metav1.DeleteOptions{})
+		}); err != nil {
 			return err
 		}
 	}
@@ -123,11 +191,9 @@ func (o *CommandDeInitOption) delete() error {
 		return err
 	}
 	for _, secret := range secrets.Items {
-		fmt.Printf("delete Secrets %q\n", secret.Name)
-		if o.DryRun {
-			continue
-		}
-		if err := secretClient.Delete(context.TODO(), secret.Name, metav1.DeleteOptions{}); err != nil {
+		if err := o.applyDelete("Secret", o.Namespace, secret.Name, func() error {
+			return secretClient.Delete(context.TODO(), secret.Name, metav1.DeleteOptions{})
+		}); err != nil {
 			return err
 		}
 	}
@@ -138,12 +204,92 @@ func (o *CommandDeInitOption) delete() error {
 	if err != nil {
 		return err
 	}
-	for _, service := range clusterRoles.Items {
-		fmt.Printf("delete ClusterRole %q\n", service.Name)
-		if o.DryRun {
-			continue
+	for _, clusterRole := range clusterRoles.Items {
+		if err := o.applyDelete("ClusterRole", "", clusterRole.Name, func() error {
+			return clusterRoleClient.Delete(context.TODO(), clusterRole.Name, metav1.DeleteOptions{})
+		}); err != nil {
+			return err
+		}
+	}
+
+	if err := o.deleteClusterScopedResources(); err != nil {
+		return err
+	}
+
+	if o.PurgeCRDs {
+		if err := o.purgeCRDs(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteClusterScopedResources removes the cluster-scoped objects that karmadactl init
+// creates outside of o.Namespace: ClusterRoleBindings, webhook configurations and
+// aggregated APIServices. These are left behind by the original delete() and otherwise
+// block a clean reinstall.
+func (o *CommandDeInitOption) deleteClusterScopedResources() error {
+	clusterRoleBindingClient := o.KubeClientSet.RbacV1().ClusterRoleBindings()
+	clusterRoleBindings, err := clusterRoleBindingClient.List(context.TODO(), metav1.ListOptions{LabelSelector: LabelSelector})
+	if err != nil {
+		return err
+	}
+	for _, crb := range clusterRoleBindings.Items {
+		if err := o.applyDelete("ClusterRoleBinding", "", crb.Name, func() error {
+			return o.deleteWithFinalizerHandling(func() error {
+				return clusterRoleBindingClient.Delete(context.TODO(), crb.Name, metav1.DeleteOptions{})
+			}, func() (metav1.Object, error) {
+				return clusterRoleBindingClient.Get(context.TODO(), crb.Name, metav1.GetOptions{})
+			}, func(finalizers []string) error {
+				obj, getErr := clusterRoleBindingClient.Get(context.TODO(), crb.Name, metav1.GetOptions{})
+				if getErr != nil {
+					return getErr
+				}
+				obj.Finalizers = finalizers
+				_, updateErr := clusterRoleBindingClient.Update(context.TODO(), obj, metav1.UpdateOptions{})
+				return updateErr
+			})
+		}); err != nil {
+			return err
+		}
+	}
+
+	mutatingWebhookClient := o.KubeClientSet.AdmissionregistrationV1().MutatingWebhookConfigurations()
+	mutatingWebhooks, err := mutatingWebhookClient.List(context.TODO(), metav1.ListOptions{LabelSelector: LabelSelector})
+	if err != nil {
+		return err
+	}
+	for _, webhook := range mutatingWebhooks.Items {
+		if err := o.applyDelete("MutatingWebhookConfiguration", "", webhook.Name, func() error {
+			return mutatingWebhookClient.Delete(context.TODO(), webhook.Name, metav1.DeleteOptions{})
+		}); err != nil {
+			return err
 		}
-		if err := clusterRoleClient.Delete(context.TODO(), service.Name, metav1.DeleteOptions{}); err != nil {
+	}
+
+	validatingWebhookClient := o.KubeClientSet.AdmissionregistrationV1().ValidatingWebhookConfigurations()
+	validatingWebhooks, err := validatingWebhookClient.List(context.TODO(), metav1.ListOptions{LabelSelector: LabelSelector})
+	if err != nil {
+		return err
+	}
+	for _, webhook := range validatingWebhooks.Items {
+		if err := o.applyDelete("ValidatingWebhookConfiguration", "", webhook.Name, func() error {
+			return validatingWebhookClient.Delete(context.TODO(), webhook.Name, metav1.DeleteOptions{})
+		}); err != nil {
+			return err
+		}
+	}
+
+	apiServiceClient := o.AggregatorClient.ApiregistrationV1().APIServices()
+	apiServices, err := apiServiceClient.List(context.TODO(), metav1.ListOptions{LabelSelector: LabelSelector})
+	if err != nil {
+		return err
+	}
+	for _, apiService := range apiServices.Items {
+		if err := o.applyDelete("APIService", "", apiService.Name, func() error {
+			return apiServiceClient.Delete(context.TODO(), apiService.Name, metav1.DeleteOptions{})
+		}); err != nil {
 			return err
 		}
 	}
@@ -151,6 +297,182 @@ func (o *CommandDeInitOption) delete() error {
 	return nil
 }
 
+// purgeCRDs removes Karmada's own CRDs (ResourceBinding, Work, PropagationPolicy, etc.),
+// deleting every instance across all namespaces first so the CRD itself isn't left
+// terminating behind its own finalizer.
+func (o *CommandDeInitOption) purgeCRDs() error {
+	crdClient := o.APIExtensionsClient.ApiextensionsV1().CustomResourceDefinitions()
+	crds, err := crdClient.List(context.TODO(), metav1.ListOptions{LabelSelector: LabelSelector})
+	if err != nil {
+		return err
+	}
+
+	for i := range crds.Items {
+		crd := &crds.Items[i]
+		gvr := crdGroupVersionResource(crd)
+
+		fmt.Printf("purge %s instances\n", crd.Spec.Names.Kind)
+		if !o.DryRun {
+			if err := o.deleteCRDInstances(crd.Spec.Names.Kind, gvr, crd.Spec.Scope == "Namespaced"); err != nil {
+				return err
+			}
+		}
+
+		if err := o.applyDelete("CustomResourceDefinition", "", crd.Name, func() error {
+			return crdClient.Delete(context.TODO(), crd.Name, metav1.DeleteOptions{})
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func crdGroupVersionResource(crd *apiextensionsv1.CustomResourceDefinition) schema.GroupVersionResource {
+	version := crd.Spec.Versions[0].Name
+	for _, v := range crd.Spec.Versions {
+		if v.Storage {
+			version = v.Name
+			break
+		}
+	}
+	return schema.GroupVersionResource{Group: crd.Spec.Group, Version: version, Resource: crd.Spec.Names.Plural}
+}
+
+func (o *CommandDeInitOption) deleteCRDInstances(kind string, gvr schema.GroupVersionResource, namespaced bool) error {
+	var resourceClient dynamic.ResourceInterface
+	if namespaced {
+		resourceClient = o.DynamicClient.Resource(gvr).Namespace(metav1.NamespaceAll)
+	} else {
+		resourceClient = o.DynamicClient.Resource(gvr)
+	}
+
+	list, err := resourceClient.List(context.TODO(), metav1.ListOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for i := range list.Items {
+		item := &list.Items[i]
+		ns := item.GetNamespace()
+		name := item.GetName()
+
+		var itemClient dynamic.ResourceInterface = resourceClient
+		if namespaced {
+			itemClient = o.DynamicClient.Resource(gvr).Namespace(ns)
+		}
+
+		if err := o.applyDeleteWithGVR(kind, ns, name, gvr, func() error {
+			return o.deleteWithFinalizerHandling(func() error {
+				return itemClient.Delete(context.TODO(), name, metav1.DeleteOptions{})
+			}, func() (metav1.Object, error) {
+				obj, getErr := itemClient.Get(context.TODO(), name, metav1.GetOptions{})
+				if getErr != nil {
+					return nil, getErr
+				}
+				return obj, nil
+			}, func(finalizers []string) error {
+				obj, getErr := itemClient.Get(context.TODO(), name, metav1.GetOptions{})
+				if getErr != nil {
+					return getErr
+				}
+				obj.SetFinalizers(finalizers)
+				_, updateErr := itemClient.Update(context.TODO(), obj, metav1.UpdateOptions{})
+				return updateErr
+			})
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteWithFinalizerHandling issues delete, and if the resource is still present after
+// o.FinalizerTimeout because a karmada.io/* finalizer is blocking it, strips those
+// finalizers and retries. This mirrors the finalizer-cleanup pattern used by tools like
+// Trident: force unblock stuck resources rather than hanging indefinitely.
+func (o *CommandDeInitOption) deleteWithFinalizerHandling(deleteFn func() error, getFn func() (metav1.Object, error), patchFn func([]string) error) error {
+	if err := deleteFn(); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	deadline := time.Now().Add(o.FinalizerTimeout)
+	for time.Now().Before(deadline) {
+		obj, err := getFn()
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if obj.GetDeletionTimestamp() == nil {
+			// not actually pending deletion (shouldn't happen after a successful delete call)
+			return nil
+		}
+
+		stuck := stuckFinalizers(obj.GetFinalizers())
+		if len(stuck) == 0 {
+			time.Sleep(time.Second)
+			continue
+		}
+
+		fmt.Printf("resource %q stuck on finalizers %v, waiting up to %s before forcing removal\n", obj.GetName(), stuck, o.FinalizerTimeout)
+		time.Sleep(time.Second)
+	}
+
+	obj, err := getFn()
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	remaining := dropFinalizers(obj.GetFinalizers(), finalizerPrefix)
+	if len(remaining) == len(obj.GetFinalizers()) {
+		// nothing we recognize to remove, give up rather than mutate state we don't understand.
+		return fmt.Errorf("resource %q did not finalize within %s and carries no karmada.io finalizer we can remove", obj.GetName(), o.FinalizerTimeout)
+	}
+
+	fmt.Printf("forcing removal of karmada.io finalizers on %q\n", obj.GetName())
+	if err := patchFn(remaining); err != nil {
+		return err
+	}
+
+	return deleteFn()
+}
+
+func stuckFinalizers(finalizers []string) []string {
+	return dropFinalizers(finalizers, "")
+}
+
+// dropFinalizers returns the finalizers that do NOT have the given prefix; passing an
+// empty prefix returns every finalizer that matches finalizerPrefix instead (used to
+// report what's currently stuck).
+func dropFinalizers(finalizers []string, prefix string) []string {
+	if prefix == "" {
+		var stuck []string
+		for _, f := range finalizers {
+			if strings.HasPrefix(f, finalizerPrefix) {
+				stuck = append(stuck, f)
+			}
+		}
+		return stuck
+	}
+
+	var remaining []string
+	for _, f := range finalizers {
+		if !strings.HasPrefix(f, prefix) {
+			remaining = append(remaining, f)
+		}
+	}
+	return remaining
+}
+
 func (o *CommandDeInitOption) deleteWorkload() error {
 	// Delete deployment by label LabelSelector
 	deploymentClient := o.KubeClientSet.AppsV1().Deployments(o.Namespace)
@@ -159,11 +481,9 @@ func (o *CommandDeInitOption) deleteWorkload() error {
 		return err
 	}
 	for _, deployment := range deployments.Items {
-		fmt.Printf("delete deployment %q\n", deployment.Name)
-		if o.DryRun {
-			continue
-		}
-		if err := deploymentClient.Delete(context.TODO(), deployment.Name, metav1.DeleteOptions{}); err != nil {
+		if err := o.applyDelete("Deployment", o.Namespace, deployment.Name, func() error {
+			return deploymentClient.Delete(context.TODO(), deployment.Name, metav1.DeleteOptions{})
+		}); err != nil {
 			return err
 		}
 	}
@@ -176,11 +496,9 @@ func (o *CommandDeInitOption) deleteWorkload() error {
 	}
 
 	for _, statefulSet := range statefulSets.Items {
-		fmt.Printf("delete StatefulSet: %q\n", statefulSet.Name)
-		if o.DryRun {
-			continue
-		}
-		if err := statefulSetClient.Delete(context.TODO(), statefulSet.Name, metav1.DeleteOptions{}); err != nil {
+		if err := o.applyDelete("StatefulSet", o.Namespace, statefulSet.Name, func() error {
+			return statefulSetClient.Delete(context.TODO(), statefulSet.Name, metav1.DeleteOptions{})
+		}); err != nil {
 			return err
 		}
 	}
@@ -220,23 +538,31 @@ func removeLabels(node *corev1.Node, removesLabel string) {
 	}
 }
 
-// deleteConfirmation delete karmada confirmation
-func deleteConfirmation() bool {
+// deleteConfirmation asks the user to confirm the delete, unless o.Yes was passed. A
+// non-TTY stdin without --yes is refused outright rather than hanging on fmt.Scanln,
+// so deinit is safe to run from CI pipelines and other non-interactive invocations.
+func (o *CommandDeInitOption) deleteConfirmation() (bool, error) {
+	if o.Yes {
+		return true, nil
+	}
+
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return false, fmt.Errorf("stdin is not a terminal, refusing to prompt for confirmation; pass --yes/-y to proceed non-interactively")
+	}
+
 	fmt.Println("Please type (y)es or (n)o and then press enter:")
 	var response string
-	_, err := fmt.Scanln(&response)
-	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+	if _, err := fmt.Scanln(&response); err != nil {
+		return false, err
 	}
 
 	switch strings.ToLower(response) {
 	case "y", "yes":
-		return true
+		return true, nil
 	case "n", "no":
-		return false
+		return false, nil
 	default:
-		return deleteConfirmation()
+		return o.deleteConfirmation()
 	}
 }
 
@@ -244,19 +570,71 @@ func deleteConfirmation() bool {
 func (o *CommandDeInitOption) Run() error {
 	fmt.Println("removes Karmada from Kubernetes")
 	// delete confirmation,exit the delete action when false.
-	if !deleteConfirmation() {
+	confirmed, err := o.deleteConfirmation()
+	if err != nil {
+		return err
+	}
+	if !confirmed {
 		return nil
 	}
 
-	if err := o.delete(); err != nil {
-		return err
+	if o.EtcdSnapshotDir != "" {
+		if err := o.snapshotEtcd(); err != nil {
+			return err
+		}
+	}
+
+	if o.FromManifest != "" {
+		if err := o.runFromManifest(); err != nil {
+			return err
+		}
+	} else {
+		if err := o.delete(); err != nil {
+			return err
+		}
+
+		if o.PurgeEtcdPVCs {
+			if err := o.purgeEtcdPVCs(); err != nil {
+				return err
+			}
+		}
+
+		if o.PurgeEtcdHostPath {
+			if err := o.purgeEtcdHostPath(); err != nil {
+				return err
+			}
+		}
+
+		if err := o.removeNodeLabels(); err != nil {
+			return err
+		}
 	}
 
-	if err := o.removeNodeLabels(); err != nil {
+	// waitForDeletion runs before printReport so -o json|yaml reflects the deinit's
+	// real final outcome (including any resource still stuck after --wait-timeout)
+	// instead of just the immediate delete-call result.
+	waitErr := o.waitForDeletion()
+
+	if err := o.printReport(); err != nil {
 		return err
 	}
 
-	fmt.Println("remove Karmada from Kubernetes successfully." +
-		"\ndeinit will not delete etcd data, if the etcd data is persistent, please delete it yourself.")
+	if waitErr != nil {
+		return waitErr
+	}
+
+	// With --force, failed deletes were only warned about as the sweep went on; list
+	// them together here instead of reporting success.
+	var failed []string
+	for _, entry := range o.report {
+		if entry.Action == actionFailed {
+			failed = append(failed, fmt.Sprintf("%s %q: %s", entry.Kind, entry.Name, entry.Error))
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to delete %d resource(s): %v", len(failed), failed)
+	}
+
+	fmt.Println("remove Karmada from Kubernetes successfully.")
 	return nil
 }
@@ -0,0 +1,232 @@
+package karmadactl
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	batchv1client "k8s.io/client-go/kubernetes/typed/batch/v1"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+const (
+	// etcdLabelSelector picks out the etcd Pods among everything karmadactl init
+	// deploys into the Karmada namespace.
+	etcdLabelSelector = "app=etcd"
+
+	// etcdSnapshotPath is where etcdctl is asked to write the snapshot inside the pod
+	// before it's streamed back to the local machine.
+	etcdSnapshotPath = "/tmp/karmadactl-etcd-snapshot.db"
+
+	// etcdHostPathDir is the hostPath directory local etcd persists its data under,
+	// as laid out by karmadactl init.
+	etcdHostPathDir = "/var/lib/karmada-etcd"
+
+	// etcdHostPathWipeImage runs the one-shot Job that clears etcdHostPathDir on a node.
+	etcdHostPathWipeImage = "busybox:1.36"
+)
+
+// snapshotEtcd runs `etcdctl snapshot save` inside each etcd pod, in the style of the
+// Clover/Trident CLIs, and copies the resulting file back to EtcdSnapshotDir. It must
+// run before delete() removes the etcd StatefulSet.
+func (o *CommandDeInitOption) snapshotEtcd() error {
+	if err := os.MkdirAll(o.EtcdSnapshotDir, 0o755); err != nil {
+		return err
+	}
+
+	pods, err := o.KubeClientSet.CoreV1().Pods(o.Namespace).List(context.TODO(), metav1.ListOptions{LabelSelector: etcdLabelSelector})
+	if err != nil {
+		return err
+	}
+	if len(pods.Items) == 0 {
+		fmt.Printf("no etcd pod found by label %q, skip etcd snapshot\n", etcdLabelSelector)
+		return nil
+	}
+
+	for _, pod := range pods.Items {
+		fmt.Printf("snapshot etcd data from pod %q\n", pod.Name)
+		if o.DryRun {
+			continue
+		}
+		if err := o.snapshotEtcdPod(pod.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o *CommandDeInitOption) snapshotEtcdPod(podName string) error {
+	saveCmd := []string{"sh", "-c", fmt.Sprintf("ETCDCTL_API=3 etcdctl snapshot save %s", etcdSnapshotPath)}
+	var stderr bytes.Buffer
+	if err := o.execInPod(podName, saveCmd, io.Discard, &stderr); err != nil {
+		return fmt.Errorf("etcdctl snapshot save failed on pod %q: %v (%s)", podName, err, stderr.String())
+	}
+
+	localPath := filepath.Join(o.EtcdSnapshotDir, podName+".db")
+	out, err := os.Create(localPath)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	stderr.Reset()
+	if err := o.execInPod(podName, []string{"cat", etcdSnapshotPath}, out, &stderr); err != nil {
+		return fmt.Errorf("failed to copy etcd snapshot from pod %q: %v (%s)", podName, err, stderr.String())
+	}
+
+	fmt.Printf("saved etcd snapshot for pod %q to %q\n", podName, localPath)
+	return nil
+}
+
+// execInPod runs command in podName and streams its stdout/stderr, the way `kubectl
+// exec`/`kubectl cp` do under the hood.
+func (o *CommandDeInitOption) execInPod(podName string, command []string, stdout, stderr io.Writer) error {
+	req := o.KubeClientSet.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Name(podName).
+		Namespace(o.Namespace).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Command: command,
+			Stdout:  true,
+			Stderr:  true,
+		}, scheme.ParameterCodec)
+
+	executor, err := remotecommand.NewSPDYExecutor(o.RestConfig, "POST", req.URL())
+	if err != nil {
+		return err
+	}
+
+	return executor.Stream(remotecommand.StreamOptions{
+		Stdout: stdout,
+		Stderr: stderr,
+	})
+}
+
+// purgeEtcdPVCs removes the PersistentVolumeClaims backing etcd. Run after the etcd
+// StatefulSet is gone so the PVCs aren't still mounted.
+func (o *CommandDeInitOption) purgeEtcdPVCs() error {
+	pvcClient := o.KubeClientSet.CoreV1().PersistentVolumeClaims(o.Namespace)
+	pvcs, err := pvcClient.List(context.TODO(), metav1.ListOptions{LabelSelector: LabelSelector})
+	if err != nil {
+		return err
+	}
+
+	for _, pvc := range pvcs.Items {
+		if err := o.applyDelete("PersistentVolumeClaim", o.Namespace, pvc.Name, func() error {
+			return pvcClient.Delete(context.TODO(), pvc.Name, metav1.DeleteOptions{})
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// purgeEtcdHostPath schedules a privileged one-shot Job on each node labeled
+// karmada.io/etcd to remove etcdHostPathDir, and waits for it to finish. It runs
+// before removeNodeLabels so the node label isn't dropped until the wipe succeeds.
+func (o *CommandDeInitOption) purgeEtcdHostPath() error {
+	nodes, err := o.KubeClientSet.CoreV1().Nodes().List(context.TODO(), metav1.ListOptions{LabelSelector: karmadaNodeLabel})
+	if err != nil {
+		return err
+	}
+	if len(nodes.Items) == 0 {
+		fmt.Printf("no node found by label %q, skip etcd hostPath purge\n", karmadaNodeLabel)
+		return nil
+	}
+
+	for _, node := range nodes.Items {
+		fmt.Printf("purge etcd hostPath data on node %q\n", node.Name)
+		if o.DryRun {
+			continue
+		}
+		if err := o.purgeEtcdHostPathOnNode(node.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o *CommandDeInitOption) purgeEtcdHostPathOnNode(nodeName string) error {
+	jobClient := o.KubeClientSet.BatchV1().Jobs(o.Namespace)
+	jobName := fmt.Sprintf("karmada-etcd-hostpath-wipe-%s", nodeName)
+
+	privileged := true
+	backoffLimit := int32(1)
+	hostPathType := corev1.HostPathDirectoryOrCreate
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobName,
+			Namespace: o.Namespace,
+			Labels:    map[string]string{LabelSelector: ""},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					NodeName:      nodeName,
+					Containers: []corev1.Container{
+						{
+							Name:            "wipe-etcd-hostpath",
+							Image:           etcdHostPathWipeImage,
+							Command:         []string{"sh", "-c", fmt.Sprintf("rm -rf %s/*", etcdHostPathDir)},
+							SecurityContext: &corev1.SecurityContext{Privileged: &privileged},
+							VolumeMounts:    []corev1.VolumeMount{{Name: "etcd-data", MountPath: etcdHostPathDir}},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: "etcd-data",
+							VolumeSource: corev1.VolumeSource{
+								HostPath: &corev1.HostPathVolumeSource{Path: etcdHostPathDir, Type: &hostPathType},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if _, err := jobClient.Create(context.TODO(), job, metav1.CreateOptions{}); err != nil {
+		return err
+	}
+	defer func() {
+		background := metav1.DeletePropagationBackground
+		_ = jobClient.Delete(context.TODO(), jobName, metav1.DeleteOptions{PropagationPolicy: &background})
+	}()
+
+	return o.waitForJobCompletion(jobClient, jobName)
+}
+
+func (o *CommandDeInitOption) waitForJobCompletion(jobClient batchv1client.JobInterface, jobName string) error {
+	timeout := o.WaitTimeout
+	if timeout <= 0 {
+		timeout = defaultWaitTimeout
+	}
+	deadline := time.Now().Add(timeout)
+
+	for time.Now().Before(deadline) {
+		job, err := jobClient.Get(context.TODO(), jobName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if job.Status.Succeeded > 0 {
+			return nil
+		}
+		if job.Status.Failed > 0 {
+			return fmt.Errorf("hostPath wipe job %q failed", jobName)
+		}
+		time.Sleep(time.Second)
+	}
+
+	return fmt.Errorf("timed out waiting for hostPath wipe job %q to complete", jobName)
+}
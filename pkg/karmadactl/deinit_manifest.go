@@ -0,0 +1,118 @@
+package karmadactl
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/restmapper"
+	"sigs.k8s.io/yaml"
+)
+
+// ManifestObject identifies a single object that karmadactl init applied to the
+// cluster, recorded so a later deinit can remove exactly what was created without
+// depending on the karmada.io/bootstrapping label being present or correct.
+type ManifestObject struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+}
+
+// Manifest is the install record written by `karmadactl init` and consumed by
+// `karmadactl deinit --from-manifest`. Objects is ordered the way init applied it;
+// deinit walks it in reverse so dependents are removed before what they depend on.
+type Manifest struct {
+	Objects []ManifestObject `json:"objects"`
+	// NodeLabels maps node name to the labels init added on that node.
+	NodeLabels map[string]map[string]string `json:"nodeLabels,omitempty"`
+}
+
+// loadManifest reads and parses a manifest file written by `karmadactl init`.
+func loadManifest(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &Manifest{}
+	if err := yaml.Unmarshal(data, manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest %q: %v", path, err)
+	}
+	return manifest, nil
+}
+
+// runFromManifest reverses an init manifest: it walks the recorded objects in
+// reverse order, deleting each one through the dynamic client, then removes the
+// node labels init added. This is the label-independent counterpart to delete(),
+// for clusters where Karmada was installed from customized YAML.
+func (o *CommandDeInitOption) runFromManifest() error {
+	manifest, err := loadManifest(o.FromManifest)
+	if err != nil {
+		return err
+	}
+
+	mapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(discovery.NewDiscoveryClient(o.KubeClientSet.RESTClient())))
+
+	for i := len(manifest.Objects) - 1; i >= 0; i-- {
+		obj := manifest.Objects[i]
+		if err := o.deleteManifestObject(mapper, obj); err != nil {
+			return err
+		}
+	}
+
+	for nodeName, labels := range manifest.NodeLabels {
+		if err := o.removeManifestNodeLabels(nodeName, labels); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (o *CommandDeInitOption) deleteManifestObject(mapper *restmapper.DeferredDiscoveryRESTMapper, obj ManifestObject) error {
+	gv, err := schema.ParseGroupVersion(obj.APIVersion)
+	if err != nil {
+		return err
+	}
+
+	mapping, err := mapper.RESTMapping(gv.WithKind(obj.Kind).GroupKind(), gv.Version)
+	if err != nil {
+		return err
+	}
+
+	resourceClient := o.DynamicClient.Resource(mapping.Resource)
+	return o.applyDeleteWithGVR(obj.Kind, obj.Namespace, obj.Name, mapping.Resource, func() error {
+		if obj.Namespace != "" {
+			return resourceClient.Namespace(obj.Namespace).Delete(context.TODO(), obj.Name, metav1.DeleteOptions{})
+		}
+		return resourceClient.Delete(context.TODO(), obj.Name, metav1.DeleteOptions{})
+	})
+}
+
+func (o *CommandDeInitOption) removeManifestNodeLabels(nodeName string, labels map[string]string) error {
+	nodeClient := o.KubeClientSet.CoreV1().Nodes()
+	node, err := nodeClient.Get(context.TODO(), nodeName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for key := range labels {
+		delete(node.Labels, key)
+	}
+	fmt.Printf("remove node %q labels %v (from manifest)\n", nodeName, labels)
+	if o.DryRun {
+		return nil
+	}
+
+	_, err = nodeClient.Update(context.TODO(), node, metav1.UpdateOptions{})
+	return err
+}
@@ -0,0 +1,126 @@
+package karmadactl
+
+import (
+	"encoding/json"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
+)
+
+// Resource deletion outcomes recorded in the -o json|yaml report.
+const (
+	actionDeleted      = "deleted"
+	actionSkipped      = "skipped"
+	actionFailed       = "failed"
+	actionDryRun       = "dry-run"
+	actionStillPresent = "still-present"
+)
+
+// resourceReport is one line of the structured report emitted by -o json|yaml: every
+// object deinit considered, what it did with it, and how that went.
+type resourceReport struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	Action    string `json:"action"`
+	Error     string `json:"error,omitempty"`
+}
+
+// record appends a line to the structured report.
+func (o *CommandDeInitOption) record(kind, namespace, name, action string, err error) {
+	entry := resourceReport{Kind: kind, Namespace: namespace, Name: name, Action: action}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	o.report = append(o.report, entry)
+}
+
+// applyDelete runs deleteFn for a single object considered by the label-based sweep,
+// recording its outcome and honoring DryRun/Force: with --force a failed delete is
+// recorded and swallowed so the sweep continues instead of aborting on the first error.
+// Kinds deleted this way are expected to be covered by one of the static informers
+// waitForDeletion registers; use applyDeleteWithGVR for kinds that aren't (dynamic CRD
+// instances, manifest objects of arbitrary kind).
+func (o *CommandDeInitOption) applyDelete(kind, namespace, name string, deleteFn func() error) error {
+	return o.applyDeleteWithGVR(kind, namespace, name, schema.GroupVersionResource{}, deleteFn)
+}
+
+// applyDeleteWithGVR is applyDelete for a kind waitForDeletion has no static informer
+// for. gvr lets waitForDeletion fall back to polling the dynamic client for this
+// specific object instead of only watching a fixed kind list.
+func (o *CommandDeInitOption) applyDeleteWithGVR(kind, namespace, name string, gvr schema.GroupVersionResource, deleteFn func() error) error {
+	if namespace != "" {
+		fmt.Printf("delete %s %q in namespace %q\n", kind, name, namespace)
+	} else {
+		fmt.Printf("delete %s %q\n", kind, name)
+	}
+
+	if o.DryRun {
+		o.record(kind, namespace, name, actionDryRun, nil)
+		return nil
+	}
+
+	err := deleteFn()
+	switch {
+	case err == nil:
+		o.record(kind, namespace, name, actionDeleted, nil)
+		o.trackPending(kind, namespace, name, gvr)
+		return nil
+	case apierrors.IsNotFound(err):
+		o.record(kind, namespace, name, actionSkipped, nil)
+		return nil
+	default:
+		o.record(kind, namespace, name, actionFailed, err)
+		if o.Force {
+			fmt.Printf("warning: failed to delete %s %q: %v (continuing due to --force)\n", kind, name, err)
+			return nil
+		}
+		return err
+	}
+}
+
+// recordStillPresent overwrites the report entries for resources waitForDeletion gave
+// up on, so -o json|yaml reflects the deinit's real final outcome instead of just the
+// immediate delete-call result.
+func (o *CommandDeInitOption) recordStillPresent(remaining map[pendingResource]struct{}) {
+	for key := range remaining {
+		for i := range o.report {
+			entry := &o.report[i]
+			if entry.Kind == key.Kind && entry.Namespace == key.Namespace && entry.Name == key.Name {
+				entry.Action = actionStillPresent
+				entry.Error = "timed out waiting for resource to finalize"
+			}
+		}
+	}
+}
+
+// printReport renders the structured report in the requested output format. It is a
+// no-op when o.Output is empty so default invocations keep the plain log lines above.
+// o.Output is validated in Complete(), before any delete runs, so a typo here is
+// unreachable.
+func (o *CommandDeInitOption) printReport() error {
+	if o.Output == "" {
+		return nil
+	}
+
+	switch o.Output {
+	case "json":
+		data, err := json.MarshalIndent(o.report, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+	case "yaml":
+		data, err := yaml.Marshal(o.report)
+		if err != nil {
+			return err
+		}
+		fmt.Print(string(data))
+	default:
+		return fmt.Errorf("unsupported output format %q, must be one of: json, yaml", o.Output)
+	}
+
+	return nil
+}
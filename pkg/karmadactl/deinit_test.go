@@ -0,0 +1,22 @@
+package karmadactl
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStuckFinalizers(t *testing.T) {
+	finalizers := []string{"karmada.io/resourcebinding-controller", "kubernetes.io/pv-protection"}
+	want := []string{"karmada.io/resourcebinding-controller"}
+	if got := stuckFinalizers(finalizers); !reflect.DeepEqual(got, want) {
+		t.Errorf("stuckFinalizers() = %v, want %v", got, want)
+	}
+}
+
+func TestDropFinalizers(t *testing.T) {
+	finalizers := []string{"karmada.io/resourcebinding-controller", "kubernetes.io/pv-protection"}
+	want := []string{"kubernetes.io/pv-protection"}
+	if got := dropFinalizers(finalizers, finalizerPrefix); !reflect.DeepEqual(got, want) {
+		t.Errorf("dropFinalizers() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,225 @@
+package karmadactl
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	apiextensionsinformers "k8s.io/apiextensions-apiserver/pkg/client/informers/externalversions"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+	aggregatorinformers "k8s.io/kube-aggregator/pkg/client/informers/externalversions"
+)
+
+// defaultWaitTimeout is how long deinit blocks waiting for deleted resources to
+// actually disappear before giving up and reporting what's left.
+const defaultWaitTimeout = 5 * time.Minute
+
+// pendingResource identifies a single object that delete() asked the API server to
+// remove and that waitForDeletion should confirm is actually gone. GVR is set only for
+// kinds waitForDeletion has no static informer for (dynamic CRD instances, manifest
+// objects of arbitrary kind); waitForDeletion falls back to polling those through
+// o.DynamicClient instead of watching for them.
+type pendingResource struct {
+	Kind      string
+	Namespace string
+	Name      string
+	GVR       schema.GroupVersionResource
+}
+
+func (p pendingResource) String() string {
+	if p.Namespace == "" {
+		return fmt.Sprintf("%s/%s", p.Kind, p.Name)
+	}
+	return fmt.Sprintf("%s/%s/%s", p.Kind, p.Namespace, p.Name)
+}
+
+// trackPending records a resource that was just handed to the API server for
+// deletion so waitForDeletion knows to watch for it. It is a no-op in dry-run mode
+// since nothing was actually deleted. gvr is the zero value for kinds covered by one
+// of waitForDeletion's static informers.
+func (o *CommandDeInitOption) trackPending(kind, namespace, name string, gvr schema.GroupVersionResource) {
+	if o.DryRun {
+		return
+	}
+	o.pending = append(o.pending, pendingResource{Kind: kind, Namespace: namespace, Name: name, GVR: gvr})
+}
+
+// waitForDeletion blocks until every resource tracked via trackPending has actually
+// disappeared from the API server, or o.WaitTimeout elapses. Resources of a kind
+// covered by a static informer (registered below) are watched; everything else
+// (dynamic CRD instances, manifest objects) is polled through o.DynamicClient using
+// the GVR trackPending recorded for it, so waitForDeletion isn't limited to a fixed
+// kind list.
+func (o *CommandDeInitOption) waitForDeletion() error {
+	if len(o.pending) == 0 {
+		return nil
+	}
+
+	remaining := make(map[pendingResource]struct{}, len(o.pending))
+	var dynamicPending []pendingResource
+	for _, r := range o.pending {
+		remaining[r] = struct{}{}
+		if r.GVR != (schema.GroupVersionResource{}) {
+			dynamicPending = append(dynamicPending, r)
+		}
+	}
+
+	var mu sync.Mutex
+	onDelete := func(kind string) func(obj interface{}) {
+		return func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			accessor, err := apimeta.Accessor(obj)
+			if err != nil {
+				return
+			}
+
+			key := pendingResource{Kind: kind, Namespace: accessor.GetNamespace(), Name: accessor.GetName()}
+			mu.Lock()
+			if _, ok := remaining[key]; ok {
+				delete(remaining, key)
+				fmt.Printf("%s finalized\n", key)
+			}
+			mu.Unlock()
+		}
+	}
+
+	storesByKind := map[string]cache.Store{}
+	register := func(informer cache.SharedIndexInformer, kind string) {
+		informer.AddEventHandler(cache.ResourceEventHandlerFuncs{DeleteFunc: onDelete(kind)})
+		storesByKind[kind] = informer.GetStore()
+	}
+
+	// Deployment, StatefulSet, Service, Secret and PersistentVolumeClaim are all
+	// namespaced, and every delete() issues for them is scoped to o.Namespace, so
+	// watch only that namespace instead of the whole cluster.
+	namespacedFactory := informers.NewSharedInformerFactoryWithOptions(o.KubeClientSet, 0, informers.WithNamespace(o.Namespace))
+	register(namespacedFactory.Apps().V1().Deployments().Informer(), "Deployment")
+	register(namespacedFactory.Apps().V1().StatefulSets().Informer(), "StatefulSet")
+	register(namespacedFactory.Core().V1().Services().Informer(), "Service")
+	register(namespacedFactory.Core().V1().Secrets().Informer(), "Secret")
+	register(namespacedFactory.Core().V1().PersistentVolumeClaims().Informer(), "PersistentVolumeClaim")
+
+	// ClusterRole, ClusterRoleBinding and the webhook configurations are cluster-scoped,
+	// so they need their own unscoped factory.
+	clusterFactory := informers.NewSharedInformerFactory(o.KubeClientSet, 0)
+	register(clusterFactory.Rbac().V1().ClusterRoles().Informer(), "ClusterRole")
+	register(clusterFactory.Rbac().V1().ClusterRoleBindings().Informer(), "ClusterRoleBinding")
+	register(clusterFactory.Admissionregistration().V1().MutatingWebhookConfigurations().Informer(), "MutatingWebhookConfiguration")
+	register(clusterFactory.Admissionregistration().V1().ValidatingWebhookConfigurations().Informer(), "ValidatingWebhookConfiguration")
+
+	// CRDs and APIServices aren't served by KubeClientSet, so purgeCRDs (--purge-crds)
+	// and the aggregated-APIService sweep would otherwise be tracked in o.pending with
+	// no informer ever able to clear them, blocking every run that uses --purge-crds
+	// for the full --wait-timeout.
+	crdFactory := apiextensionsinformers.NewSharedInformerFactory(o.APIExtensionsClient, 0)
+	register(crdFactory.Apiextensions().V1().CustomResourceDefinitions().Informer(), "CustomResourceDefinition")
+
+	apiServiceFactory := aggregatorinformers.NewSharedInformerFactory(o.AggregatorClient, 0)
+	register(apiServiceFactory.Apiregistration().V1().APIServices().Informer(), "APIService")
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	namespacedFactory.Start(stopCh)
+	namespacedFactory.WaitForCacheSync(stopCh)
+	clusterFactory.Start(stopCh)
+	clusterFactory.WaitForCacheSync(stopCh)
+	crdFactory.Start(stopCh)
+	crdFactory.WaitForCacheSync(stopCh)
+	apiServiceFactory.Start(stopCh)
+	apiServiceFactory.WaitForCacheSync(stopCh)
+
+	// An object already gone before the caches synced never produces a Delete event, so
+	// clear every informer-watched resource that the synced cache does not hold.
+	mu.Lock()
+	for r := range remaining {
+		if store, ok := storesByKind[r.Kind]; ok && r.GVR == (schema.GroupVersionResource{}) {
+			if _, exists, err := store.GetByKey(strings.TrimPrefix(r.Namespace+"/"+r.Name, "/")); err == nil && !exists {
+				delete(remaining, r)
+				fmt.Printf("%s finalized\n", r)
+			}
+		}
+	}
+	mu.Unlock()
+
+	timeout := o.WaitTimeout
+	if timeout <= 0 {
+		timeout = defaultWaitTimeout
+	}
+	deadline := time.Now().Add(timeout)
+
+	for {
+		o.pollDynamicPending(dynamicPending, remaining, &mu)
+
+		mu.Lock()
+		left := len(remaining)
+		mu.Unlock()
+		if left == 0 {
+			fmt.Println("all tracked resources finalized")
+			return nil
+		}
+		if time.Now().After(deadline) {
+			mu.Lock()
+			defer mu.Unlock()
+			o.recordStillPresent(remaining)
+			return newWaitTimeoutError(remaining)
+		}
+		time.Sleep(time.Second)
+	}
+}
+
+// pollDynamicPending checks each resource in pending that's still in remaining via a
+// direct Get through o.DynamicClient, removing it once the API server reports it
+// NotFound. This is the fallback for kinds waitForDeletion has no informer for.
+func (o *CommandDeInitOption) pollDynamicPending(pending []pendingResource, remaining map[pendingResource]struct{}, mu *sync.Mutex) {
+	for _, r := range pending {
+		mu.Lock()
+		_, stillPending := remaining[r]
+		mu.Unlock()
+		if !stillPending {
+			continue
+		}
+
+		var resourceClient dynamic.ResourceInterface = o.DynamicClient.Resource(r.GVR)
+		if r.Namespace != "" {
+			resourceClient = o.DynamicClient.Resource(r.GVR).Namespace(r.Namespace)
+		}
+
+		_, err := resourceClient.Get(context.TODO(), r.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			mu.Lock()
+			delete(remaining, r)
+			mu.Unlock()
+			fmt.Printf("%s finalized\n", r)
+		}
+	}
+}
+
+// waitTimeoutError reports the resources that were still present when waitForDeletion
+// gave up.
+type waitTimeoutError struct {
+	Remaining []string
+}
+
+func newWaitTimeoutError(remaining map[pendingResource]struct{}) error {
+	names := make([]string, 0, len(remaining))
+	for r := range remaining {
+		names = append(names, r.String())
+	}
+	sort.Strings(names)
+	return &waitTimeoutError{Remaining: names}
+}
+
+func (e *waitTimeoutError) Error() string {
+	return fmt.Sprintf("timed out waiting for %d resource(s) to finalize: %v", len(e.Remaining), e.Remaining)
+}
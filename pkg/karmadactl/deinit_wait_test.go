@@ -0,0 +1,35 @@
+package karmadactl
+
+import "testing"
+
+func TestPendingResourceString(t *testing.T) {
+	cases := []struct {
+		name string
+		r    pendingResource
+		want string
+	}{
+		{"namespaced", pendingResource{Kind: "Secret", Namespace: "karmada-system", Name: "karmada-cert"}, "Secret/karmada-system/karmada-cert"},
+		{"cluster-scoped", pendingResource{Kind: "ClusterRole", Name: "karmada-controller-manager"}, "ClusterRole/karmada-controller-manager"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := c.r.String(); got != c.want {
+				t.Errorf("String() = %q, want %q", got, c.want)
+			}
+		})
+	}
+}
+
+func TestNewWaitTimeoutError(t *testing.T) {
+	remaining := map[pendingResource]struct{}{
+		{Kind: "Secret", Namespace: "karmada-system", Name: "b"}: {},
+		{Kind: "Secret", Namespace: "karmada-system", Name: "a"}: {},
+	}
+
+	err := newWaitTimeoutError(remaining)
+	want := "timed out waiting for 2 resource(s) to finalize: [Secret/karmada-system/a Secret/karmada-system/b]"
+	if err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
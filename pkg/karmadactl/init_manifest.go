@@ -0,0 +1,63 @@
+package karmadactl
+
+import (
+	"os"
+	"sync"
+
+	"sigs.k8s.io/yaml"
+)
+
+// ManifestRecorder accumulates the objects and node labels `karmadactl init` applies
+// to the cluster, in apply order, so they can be written out as a Manifest once init
+// finishes. `karmadactl deinit --from-manifest` reads that file back and reverses it.
+//
+// init's apply loop is expected to call RecordObject right after each object it
+// creates succeeds, and RecordNodeLabel after each node label it adds, then Save once
+// at the end. RecordObject/RecordNodeLabel are safe to call concurrently since init
+// parallelizes some of its apply steps.
+type ManifestRecorder struct {
+	mu       sync.Mutex
+	manifest Manifest
+}
+
+// NewManifestRecorder returns an empty ManifestRecorder ready to record an init run.
+func NewManifestRecorder() *ManifestRecorder {
+	return &ManifestRecorder{manifest: Manifest{NodeLabels: map[string]map[string]string{}}}
+}
+
+// RecordObject records a single object init successfully applied.
+func (r *ManifestRecorder) RecordObject(apiVersion, kind, namespace, name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.manifest.Objects = append(r.manifest.Objects, ManifestObject{
+		APIVersion: apiVersion,
+		Kind:       kind,
+		Namespace:  namespace,
+		Name:       name,
+	})
+}
+
+// RecordNodeLabel records a label init added to a node.
+func (r *ManifestRecorder) RecordNodeLabel(nodeName, key, value string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	labels, ok := r.manifest.NodeLabels[nodeName]
+	if !ok {
+		labels = map[string]string{}
+		r.manifest.NodeLabels[nodeName] = labels
+	}
+	labels[key] = value
+}
+
+// Save writes the recorded manifest to path, creating or truncating it. Call this
+// once, after init's last apply step succeeds.
+func (r *ManifestRecorder) Save(path string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	data, err := yaml.Marshal(&r.manifest)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
@@ -0,0 +1,37 @@
+package karmadactl
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestManifestRecorderRoundTrip(t *testing.T) {
+	recorder := NewManifestRecorder()
+	recorder.RecordObject("v1", "ServiceAccount", "karmada-system", "karmada-controller-manager")
+	recorder.RecordObject("apiextensions.k8s.io/v1", "CustomResourceDefinition", "", "clusters.cluster.karmada.io")
+	recorder.RecordNodeLabel("node-1", karmadaNodeLabel, "")
+
+	path := filepath.Join(t.TempDir(), "manifest.yaml")
+	if err := recorder.Save(path); err != nil {
+		t.Fatalf("Save() returned error: %v", err)
+	}
+
+	manifest, err := loadManifest(path)
+	if err != nil {
+		t.Fatalf("loadManifest() returned error: %v", err)
+	}
+
+	want := []ManifestObject{
+		{APIVersion: "v1", Kind: "ServiceAccount", Namespace: "karmada-system", Name: "karmada-controller-manager"},
+		{APIVersion: "apiextensions.k8s.io/v1", Kind: "CustomResourceDefinition", Name: "clusters.cluster.karmada.io"},
+	}
+	if !reflect.DeepEqual(manifest.Objects, want) {
+		t.Errorf("Objects = %#v, want %#v", manifest.Objects, want)
+	}
+
+	wantLabels := map[string]map[string]string{"node-1": {karmadaNodeLabel: ""}}
+	if !reflect.DeepEqual(manifest.NodeLabels, wantLabels) {
+		t.Errorf("NodeLabels = %#v, want %#v", manifest.NodeLabels, wantLabels)
+	}
+}